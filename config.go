@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/BurntSushi/toml"
+)
+
+// defaultEnvironments mirrors the tool's original hard-coded environment
+// list, used when no --config file is supplied.
+var defaultEnvironments = []EnvironmentConfig{
+	{Prefix: "SANDBOX_", Private: true},
+	{Prefix: "DEV_", Private: true},
+	{Prefix: "STAGE_", Private: true},
+	{Prefix: "PREPROD_", Private: true, BranchProtected: true},
+	{Prefix: "PROD_", Private: true, BranchProtected: true},
+}
+
+// EnvironmentConfig describes one environment to scaffold: its directory
+// prefix and the per-env overrides for how its remote repo is created.
+type EnvironmentConfig struct {
+	Prefix          string `toml:"prefix"`
+	Private         bool   `toml:"private"`
+	Provider        string `toml:"provider"`
+	BranchProtected bool   `toml:"branch_protected"`
+}
+
+// GitIdentityConfig holds the committer identity used for scaffolded
+// commits, and the SSH key used to push them.
+type GitIdentityConfig struct {
+	Name       string `toml:"name"`
+	Email      string `toml:"email"`
+	SSHKeyPath string `toml:"ssh_key"`
+}
+
+// Config describes everything needed to run the tool non-interactively:
+// the source directory, solution name, the environments to scaffold, and
+// the git identity to commit as. It is loaded from a TOML file via
+// --config so the tool can run unattended in CI.
+type Config struct {
+	SourceDir    string              `toml:"source_dir"`
+	SolutionName string              `toml:"solution_name"`
+	Environments []EnvironmentConfig `toml:"environments"`
+	Git          GitIdentityConfig   `toml:"git"`
+}
+
+// loadConfig parses a TOML config file from path.
+func loadConfig(path string) (*Config, error) {
+	var cfg Config
+	if _, err := toml.DecodeFile(path, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file '%s': %v", path, err)
+	}
+
+	if cfg.SourceDir == "" {
+		return nil, fmt.Errorf("config file '%s' must set source_dir", path)
+	}
+	if cfg.SolutionName == "" {
+		return nil, fmt.Errorf("config file '%s' must set solution_name", path)
+	}
+	if len(cfg.Environments) == 0 {
+		cfg.Environments = defaultEnvironments
+	}
+
+	return &cfg, nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadConfigDefaultsEnvironments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	contents := `
+source_dir = "./mysolution"
+solution_name = "MySolution"
+`
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("loadConfig returned error: %v", err)
+	}
+
+	if len(cfg.Environments) != len(defaultEnvironments) {
+		t.Fatalf("Environments = %d entries, want %d (defaultEnvironments)", len(cfg.Environments), len(defaultEnvironments))
+	}
+	for i, env := range cfg.Environments {
+		if env != defaultEnvironments[i] {
+			t.Errorf("Environments[%d] = %+v, want %+v", i, env, defaultEnvironments[i])
+		}
+	}
+}
+
+func TestLoadConfigRequiresSourceDirAndSolutionName(t *testing.T) {
+	cases := []struct {
+		name     string
+		contents string
+	}{
+		{name: "missing source_dir", contents: `solution_name = "MySolution"`},
+		{name: "missing solution_name", contents: `source_dir = "./mysolution"`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.toml")
+			if err := os.WriteFile(path, []byte(tc.contents), 0644); err != nil {
+				t.Fatalf("failed to write test config: %v", err)
+			}
+
+			if _, err := loadConfig(path); err == nil {
+				t.Fatal("loadConfig returned nil error, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+)
+
+// runDryRun walks directoryName and logs, for every environment, the exact
+// directories and files that would be created or copied, the remote repo
+// that would be created, and the git commands that would run — without
+// creating anything locally or hitting the network.
+func runDryRun(directoryName, solutionName string, sink Sink, environments []EnvironmentConfig, nonInteractive bool, providerName, defaultBranch string) error {
+	var relFiles, relDirs []string
+	err := filepath.Walk(directoryName, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == directoryName {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(directoryName, path)
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			relDirs = append(relDirs, relPath)
+		} else {
+			relFiles = append(relFiles, relPath)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to walk source directory '%s': %v", directoryName, err)
+	}
+
+	baseName := filepath.Base(directoryName)
+
+	for _, env := range environments {
+		envDirName := fmt.Sprintf("%s%s", env.Prefix, baseName)
+
+		if sink == nil {
+			destRoot := filepath.Join(getDesktopSolutionsPath(), solutionName, envDirName)
+			slog.Info("dry-run: would create directory", "path", destRoot)
+			for _, dir := range relDirs {
+				slog.Info("dry-run: would create directory", "path", filepath.Join(destRoot, dir))
+			}
+			for _, file := range relFiles {
+				slog.Info("dry-run: would copy file", "src", filepath.Join(directoryName, file), "dest", filepath.Join(destRoot, file))
+			}
+		} else {
+			for _, file := range relFiles {
+				slog.Info("dry-run: would upload file", "key", fmt.Sprintf("%s/%s/%s", solutionName, envDirName, filepath.ToSlash(file)))
+			}
+			continue
+		}
+
+		envProviderName := providerName
+		if env.Provider != "" {
+			envProviderName = env.Provider
+		}
+
+		if nonInteractive {
+			slog.Info("dry-run: would create remote repository", "env", envDirName, "provider", envProviderName, "private", env.Private)
+		} else {
+			slog.Info("dry-run: would prompt to create remote repository", "env", envDirName, "provider", envProviderName, "private", env.Private)
+		}
+
+		slog.Info("dry-run: would run git init", "env", envDirName, "branch", defaultBranch)
+		slog.Info("dry-run: would run git add .", "env", envDirName)
+		slog.Info("dry-run: would run git commit", "env", envDirName, "message", fmt.Sprintf("first commit for %s", envDirName))
+		slog.Info("dry-run: would run git remote add origin", "env", envDirName, "provider", envProviderName)
+		slog.Info("dry-run: would run git push -u origin", "env", envDirName, "branch", defaultBranch)
+
+		if env.BranchProtected {
+			slog.Info("dry-run: would protect branch", "env", envDirName, "branch", defaultBranch)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunDryRunLogsWithoutTouchingFilesystem(t *testing.T) {
+	srcDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(srcDir, "file.txt"), []byte("hello"), 0644); err != nil {
+		t.Fatalf("failed to write fixture file: %v", err)
+	}
+
+	var buf bytes.Buffer
+	prevLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, nil)))
+	defer slog.SetDefault(prevLogger)
+
+	environments := []EnvironmentConfig{
+		{Prefix: "SANDBOX_"},
+		{Prefix: "PROD_", BranchProtected: true},
+	}
+
+	if err := runDryRun(srcDir, "MySolution", nil, environments, true, "github", "main"); err != nil {
+		t.Fatalf("runDryRun returned error: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"would create directory",
+		"would copy file",
+		"would create remote repository",
+		"would run git push",
+		"would protect branch",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("dry-run output missing %q, got:\n%s", want, out)
+		}
+	}
+
+	entries, err := os.ReadDir(srcDir)
+	if err != nil {
+		t.Fatalf("failed to read source dir: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("dry run mutated source directory: %d entries, want 1", len(entries))
+	}
+}
@@ -1,12 +1,11 @@
 package main
 
 import (
-	"bytes"
+	"flag"
 	"fmt"
 	"io"
-	"net/http"
+	"log/slog"
 	"os"
-  "os/exec"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -50,7 +49,7 @@ func copyDirectory(src, dest string, wg *sync.WaitGroup) {
 	})
 
 	if err != nil {
-		fmt.Printf("Error copying directory from '%s' to '%s': %v\n", src, dest, err)
+		slog.Error("failed to copy directory", "src", src, "dest", dest, "error", err)
 	}
 }
 
@@ -60,149 +59,162 @@ func getDesktopSolutionsPath() string {
 	return filepath.Join(homeDir, "Desktop", "Solutions")
 }
 
-// Prompt user if they want to create a GitHub repository
+// Prompt user if they want to create a remote repository
 func promptForGitRepo(envDirName string) bool {
 	var answer string
-	fmt.Printf("Do you want to create a new GitHub repository for the '%s' environment? (y/n): ", envDirName)
+	fmt.Printf("Do you want to create a new remote repository for the '%s' environment? (y/n): ", envDirName)
 	fmt.Scanln(&answer)
 
 	return strings.ToLower(answer) == "y"
 }
 
-// Create a GitHub repository using GitHub API
-func createGitHubRepo(envDirName string) error {
-	token := os.Getenv("GITHUB_TOKEN") // Ensure you have your GitHub token set as an environment variable
-
-	if token == "" {
-		return fmt.Errorf("GitHub token is required, but it's not set")
-	}
-
-	url := "https://api.github.com/user/repos"
-	jsonBody := fmt.Sprintf(`{"name":"%s", "private":true}`, envDirName)
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer([]byte(jsonBody)))
-	if err != nil {
-		return err
-	}
-
-	req.Header.Set("Authorization", "token "+token)
-	req.Header.Set("Content-Type", "application/json")
+// runInputs holds what main needs to scaffold a solution, whether it came
+// from a --config file or from interactive prompts.
+type runInputs struct {
+	directoryName  string
+	solutionName   string
+	environments   []EnvironmentConfig
+	git            GitIdentityConfig
+	nonInteractive bool
+}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return err
+// resolveRunInputs loads runInputs from configPath when given, falling back
+// to the original interactive prompts otherwise so the tool still works for
+// one-off local use.
+func resolveRunInputs(configPath string) (*runInputs, error) {
+	if configPath != "" {
+		cfg, err := loadConfig(configPath)
+		if err != nil {
+			return nil, err
+		}
+		return &runInputs{
+			directoryName:  cfg.SourceDir,
+			solutionName:   cfg.SolutionName,
+			environments:   cfg.Environments,
+			git:            cfg.Git,
+			nonInteractive: true,
+		}, nil
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusCreated {
-		return fmt.Errorf("failed to create repository, status code: %d", resp.StatusCode)
-	}
+	var directoryName, solutionName string
+	fmt.Print("Enter the directory name you wish to copy. If it's not in the current directory you run this script from, it will need the absolute path: ")
+	fmt.Scanln(&directoryName)
+	fmt.Print("Enter the solution name for your outer shell directory: ")
+	fmt.Scanln(&solutionName)
 
-	fmt.Printf("Successfully created GitHub repository: https://github.com/AlbersSoftware/%s\n", envDirName)
-	return nil
+	return &runInputs{
+		directoryName: directoryName,
+		solutionName:  solutionName,
+		environments:  defaultEnvironments,
+	}, nil
 }
 
-
-// Setup git for the environment directory
-func setupGitForEnv(envDirName, solutionPath string) error {
-	// Initialize Git repository
-	cmd := exec.Command("git", "init")
-	cmd.Dir = envDirName
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to initialize git repository: %v", err)
-	}
-
-	// Add all files to staging area
-	cmd = exec.Command("git", "add", ".")
-	cmd.Dir = envDirName
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to add files: %v", err)
+func main() {
+	sshKeyPath := flag.String("ssh-key", os.Getenv("SSH_KEY"), "path to an SSH private key used to push over SSH instead of HTTPS (falls back to the SSH_KEY env var)")
+	providerName := flag.String("provider", "github", "git hosting provider to create repos with: github, gitlab, gitea, or bitbucket")
+	configPath := flag.String("config", "", "path to a TOML config file; when set, runs non-interactively instead of prompting")
+	storageURL := flag.String("storage", "", "remote storage URL to snapshot environments to instead of the local filesystem, e.g. s3://bucket/path or gs://bucket/path")
+	preflightTimeout := flag.Duration("preflight-timeout", defaultPreflightTimeout, "how long to wait when checking a git provider's reachability and auth before giving up")
+	defaultBranch := flag.String("default-branch", "main", "branch name to initialize each environment repo on and push to")
+	logLevel := flag.String("log-level", "info", "minimum log level to emit: debug, info, warn, or error")
+	logFormat := flag.String("log-format", "text", "log output format: text or json")
+	dryRun := flag.Bool("dry-run", false, "print what would be created and pushed without touching the filesystem or network")
+	flag.Parse()
+
+	if err := configureLogger(*logLevel, *logFormat); err != nil {
+		fmt.Printf("Error configuring logger: %v\n", err)
+		return
 	}
 
-	// Commit the changes
-	cmd = exec.Command("git", "commit", "-m", fmt.Sprintf("first commit for %s", envDirName))
-	cmd.Dir = envDirName
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to commit changes: %v", err)
+	inputs, err := resolveRunInputs(*configPath)
+	if err != nil {
+		slog.Error("failed to load config", "error", err)
+		return
 	}
 
-	// Set the remote origin
-	remoteURL := fmt.Sprintf("https://github.com/AlbersSoftware/%s.git", envDirName)
-	cmd = exec.Command("git", "remote", "add", "origin", remoteURL)
-	cmd.Dir = filepath.Join(solutionPath, envDirName) // Ensure this is the correct working directory
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to set remote origin: %v", err)
+	sink, err := newSink(*storageURL)
+	if err != nil {
+		slog.Error("failed to set up remote storage", "error", err)
+		return
 	}
 
-	// Push to GitHub
-	cmd = exec.Command("git", "push", "-u", "origin", "master")
-	cmd.Dir = filepath.Join(solutionPath, envDirName) // Same as above
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to push to remote repository: %v", err)
+	if inputs.git.SSHKeyPath != "" {
+		*sshKeyPath = inputs.git.SSHKeyPath
 	}
 
-	fmt.Printf("Successfully pushed to %s\n", remoteURL)
-	return nil
-}
-
-
-func main() {
-	var directoryName, solutionName string
-
-	// Get input for the directory you wish to copy and the outer shell directory name to be placed in the Solutions directory.
-	fmt.Print("Enter the directory name you wish to copy. If it's not in the current directory you run this script from, it will need the absolute path: ")
-	fmt.Scanln(&directoryName)
-	fmt.Print("Enter the solution name for your outer shell directory: ")
-	fmt.Scanln(&solutionName)
+	directoryName := inputs.directoryName
+	solutionName := inputs.solutionName
 
 	// Check if the input directory exists.
 	if _, err := os.Stat(directoryName); os.IsNotExist(err) {
-		fmt.Println("The specified directory does not exist. Did you use the absolute path?")
+		slog.Error("source directory does not exist; did you use the absolute path?", "directory", directoryName)
 		return
 	}
 
-	// Create the "Solutions" directory if it doesn't exist.
-	solutionsPath := getDesktopSolutionsPath()
-	if _, err := os.Stat(solutionsPath); os.IsNotExist(err) {
-		err := os.MkdirAll(solutionsPath, 0755)
-		if err != nil {
-			fmt.Printf("Failed to create 'Solutions' directory: %v\n", err)
+	if *dryRun {
+		if err := runDryRun(directoryName, solutionName, sink, inputs.environments, inputs.nonInteractive, *providerName, *defaultBranch); err != nil {
+			slog.Error("dry run failed", "error", err)
+		}
+		return
+	}
+
+	if sink == nil {
+		// Fail fast on an unreachable or misauthenticated git provider
+		// instead of creating half the environments and stalling on push.
+		if err := runPreflightChecks(inputs.environments, *providerName, *preflightTimeout); err != nil {
+			slog.Error("pre-flight check failed", "error", err)
 			return
 		}
 	}
 
-	// Create the solution directory.
-	solutionPath := filepath.Join(solutionsPath, solutionName)
-	err := os.MkdirAll(solutionPath, 0755)
-	if err != nil {
-		fmt.Printf("Failed to create solution directory: %v\n", err)
-		return
+	var solutionPath string
+	if sink == nil {
+		// Create the "Solutions" directory if it doesn't exist.
+		solutionsPath := getDesktopSolutionsPath()
+		if _, err := os.Stat(solutionsPath); os.IsNotExist(err) {
+			err := os.MkdirAll(solutionsPath, 0755)
+			if err != nil {
+				slog.Error("failed to create 'Solutions' directory", "error", err)
+				return
+			}
+		}
+
+		// Create the solution directory.
+		solutionPath = filepath.Join(solutionsPath, solutionName)
+		if err := os.MkdirAll(solutionPath, 0755); err != nil {
+			slog.Error("failed to create solution directory", "error", err)
+			return
+		}
 	}
 
 	// Notify the user the process has started
-	fmt.Println("Hang tight while your environment bakes in the oven for a bit...")
+	slog.Info("scaffolding environments")
 
-	// List of environments.
-	environments := []string{"SANDBOX_", "DEV_", "STAGE_", "PREPROD_", "PROD_"}
+	environments := inputs.environments
 
 	// Use a wait group to wait for all goroutines to complete.
 	var wg sync.WaitGroup
 
 	// Create environment directories and copy the input directory into each concurrently.
 	for _, env := range environments {
-		envDirName := fmt.Sprintf("%s%s", env, filepath.Base(directoryName))
-		envDirPath := filepath.Join(solutionPath, envDirName)
+		envDirName := fmt.Sprintf("%s%s", env.Prefix, filepath.Base(directoryName))
 
-		err := os.MkdirAll(envDirPath, 0755)
-		if err != nil {
-			fmt.Printf("Failed to create environment directory '%s': %v\n", envDirName, err)
+		// Notify the user that we're still working.
+		slog.Info("setting up environment", "env", envDirName)
+		wg.Add(1)
+
+		if sink != nil {
+			// Stream straight to remote storage instead of the local filesystem.
+			go copyDirectoryToSink(directoryName, sink, fmt.Sprintf("%s/%s", solutionName, envDirName), &wg)
 			continue
 		}
-		// Notify the user that we're still working.
-		fmt.Printf("Still cooking... setting up %s\n", envDirName)
 
-		// Increment the WaitGroup counter for each goroutine.
-		wg.Add(1)
+		envDirPath := filepath.Join(solutionPath, envDirName)
+		if err := os.MkdirAll(envDirPath, 0755); err != nil {
+			slog.Error("failed to create environment directory", "env", envDirName, "error", err)
+			wg.Done()
+			continue
+		}
 
 		// Copy the directory concurrently.
 		go copyDirectory(directoryName, envDirPath, &wg)
@@ -211,24 +223,59 @@ func main() {
 	// Wait for all goroutines to finish.
 	wg.Wait()
 
+	slog.Info("environment setup completed successfully")
+
+	if sink != nil {
+		// Snapshots pushed to remote storage have no local working tree to
+		// turn into a git repository, so there's nothing left to push.
+		return
+	}
+
+	committerName, committerEmail, err := resolveGitIdentity(inputs.git)
+	if err != nil {
+		slog.Error("failed to resolve git identity", "error", err)
+		return
+	}
+
+	// Create remote repositories for each environment: always when driven by
+	// a config file, otherwise only when the user opts in interactively.
+	for _, env := range environments {
+		envDirName := fmt.Sprintf("%s%s", env.Prefix, filepath.Base(directoryName))
+		if !inputs.nonInteractive && !promptForGitRepo(envDirName) {
+			continue
+		}
+
+		envProviderName := *providerName
+		if env.Provider != "" {
+			envProviderName = env.Provider
+		}
+
+		provider, err := newGitProvider(envProviderName)
+		if err != nil {
+			slog.Error("failed to set up git provider", "env", envDirName, "error", err)
+			continue
+		}
 
-fmt.Println("Environment setup completed successfully!")
+		cloneURL, err := provider.CreateRepo(envDirName, env.Private)
+		if err != nil {
+			slog.Error("failed to create remote repository", "env", envDirName, "error", err)
+			continue
+		}
 
-// Ask if the user wants to create GitHub repositories for each environment
-for _, env := range environments {
-	envDirName := fmt.Sprintf("%s%s", env, filepath.Base(directoryName))
-	if promptForGitRepo(envDirName) {
-		// Create GitHub repository and set up git for each environment
-		if err := createGitHubRepo(envDirName); err != nil {
-			fmt.Printf("Error creating GitHub repository: %v\n", err)
+		if err := setupGitForEnv(envDirName, solutionPath, cloneURL, *sshKeyPath, *defaultBranch, committerName, committerEmail, provider); err != nil {
+			slog.Error("failed to set up git for environment", "env", envDirName, "error", err)
 			continue
 		}
 
-		// Set up git for the environment
-		if err := setupGitForEnv(envDirName, solutionPath); err != nil {
-			fmt.Printf("Error setting up git for %s: %v\n", envDirName, err)
+		if env.BranchProtected {
+			protector, ok := provider.(BranchProtector)
+			if !ok {
+				slog.Warn("provider does not support branch protection, skipping", "provider", envProviderName, "env", envDirName)
+				continue
+			}
+			if err := protector.ProtectBranch(envDirName, *defaultBranch); err != nil {
+				slog.Error("failed to protect branch", "env", envDirName, "error", err)
+			}
 		}
 	}
 }
-
-}
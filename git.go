@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+)
+
+// gitAuthMethod picks an auth transport for pushing to provider, preferring
+// an SSH private key (via --ssh-key / SSH_KEY) over the provider's own HTTPS
+// credentials so machines without cached HTTPS credentials still work out
+// of the box. This is what makes --provider gitlab/gitea/bitbucket actually
+// push with GITLAB_TOKEN/GITEA_TOKEN/Bitbucket credentials instead of
+// whatever GITHUB_TOKEN happens to be set to.
+func gitAuthMethod(sshKeyPath string, provider GitProvider) (transport.AuthMethod, error) {
+	if sshKeyPath != "" {
+		auth, err := ssh.NewPublicKeysFromFile("git", sshKeyPath, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to load SSH key from '%s': %v", sshKeyPath, err)
+		}
+		return auth, nil
+	}
+
+	return provider.PushAuth()
+}
+
+// sshRemoteURL converts a provider's HTTPS clone URL (e.g.
+// https://github.com/AlbersSoftware/foo.git) into its SSH equivalent (e.g.
+// git@github.com:AlbersSoftware/foo.git) so pushes can use an SSH key
+// regardless of which GitProvider created the repo.
+func sshRemoteURL(httpsURL string) (string, error) {
+	parsed, err := url.Parse(httpsURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse clone URL '%s': %v", httpsURL, err)
+	}
+
+	path := strings.TrimPrefix(parsed.Path, "/")
+	return fmt.Sprintf("git@%s:%s", parsed.Host, path), nil
+}
+
+// setupGitForEnv initializes a git repository for the environment directory
+// on defaultBranch, commits its contents as committerName/committerEmail,
+// and pushes it to cloneURL using go-git. sshKeyPath may be empty, in which
+// case provider's own credentials are used over HTTPS.
+func setupGitForEnv(envDirName, solutionPath, cloneURL, sshKeyPath, defaultBranch, committerName, committerEmail string, provider GitProvider) error {
+	envPath := filepath.Join(solutionPath, envDirName)
+
+	repo, err := git.PlainInitWithOptions(envPath, &git.PlainInitOptions{
+		InitOptions: git.InitOptions{
+			DefaultBranch: plumbing.NewBranchReferenceName(defaultBranch),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to initialize git repository: %v", err)
+	}
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree for '%s': %v", envDirName, err)
+	}
+
+	if err := worktree.AddWithOptions(&git.AddOptions{All: true}); err != nil {
+		return fmt.Errorf("failed to add files: %v", err)
+	}
+
+	_, err = worktree.Commit(fmt.Sprintf("first commit for %s", envDirName), &git.CommitOptions{
+		Author: &object.Signature{
+			Name:  committerName,
+			Email: committerEmail,
+			When:  time.Now(),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to commit changes: %v", err)
+	}
+
+	remoteURL := cloneURL
+	if sshKeyPath != "" {
+		remoteURL, err = sshRemoteURL(cloneURL)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = repo.CreateRemote(&config.RemoteConfig{
+		Name: "origin",
+		URLs: []string{remoteURL},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set remote origin: %v", err)
+	}
+
+	auth, err := gitAuthMethod(sshKeyPath, provider)
+	if err != nil {
+		return fmt.Errorf("failed to set up git credentials: %v", err)
+	}
+
+	branchRef := plumbing.NewBranchReferenceName(defaultBranch)
+	err = repo.Push(&git.PushOptions{
+		RemoteName: "origin",
+		Auth:       auth,
+		RefSpecs:   []config.RefSpec{config.RefSpec(fmt.Sprintf("%s:%s", branchRef, branchRef))},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to push to remote repository: %v", err)
+	}
+
+	slog.Info("pushed environment", "env", envDirName, "remote", remoteURL)
+	return nil
+}
@@ -0,0 +1,46 @@
+package main
+
+import "testing"
+
+func TestSSHRemoteURL(t *testing.T) {
+	cases := []struct {
+		name     string
+		httpsURL string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "github",
+			httpsURL: "https://github.com/AlbersSoftware/foo.git",
+			want:     "git@github.com:AlbersSoftware/foo.git",
+		},
+		{
+			name:     "self-hosted gitea host",
+			httpsURL: "https://git.example.com/AlbersSoftware/bar.git",
+			want:     "git@git.example.com:AlbersSoftware/bar.git",
+		},
+		{
+			name:     "invalid URL",
+			httpsURL: "://not-a-url",
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := sshRemoteURL(tc.httpsURL)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("sshRemoteURL(%q) = nil error, want error", tc.httpsURL)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("sshRemoteURL(%q) returned error: %v", tc.httpsURL, err)
+			}
+			if got != tc.want {
+				t.Errorf("sshRemoteURL(%q) = %q, want %q", tc.httpsURL, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/go-git/go-git/v5/config"
+)
+
+// resolveGitIdentity returns the committer name/email to use for scaffolded
+// commits. It checks the global git config first (the tool's original
+// assumption), falls back to the TOML config's [git] section, and finally
+// prompts interactively. The global git config is written only when a name
+// or email was typed in interactively, and then both the resolved name and
+// email are written, including one taken from the TOML config; a run driven
+// entirely by a TOML config or the existing global config must not mutate
+// the machine's ~/.gitconfig as a side effect.
+func resolveGitIdentity(configured GitIdentityConfig) (name, email string, err error) {
+	globalCfg, err := config.LoadConfig(config.GlobalScope)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read global git config: %v", err)
+	}
+
+	name = globalCfg.Raw.Section("user").Option("name")
+	email = globalCfg.Raw.Section("user").Option("email")
+
+	if name != "" && email != "" {
+		return name, email, nil
+	}
+
+	if name == "" {
+		name = configured.Name
+	}
+	if email == "" {
+		email = configured.Email
+	}
+
+	var promptedName, promptedEmail bool
+	if name == "" {
+		fmt.Print("git config user.name is not set. Enter the name to commit as: ")
+		fmt.Scanln(&name)
+		promptedName = true
+	}
+	if email == "" {
+		fmt.Print("git config user.email is not set. Enter the email to commit as: ")
+		fmt.Scanln(&email)
+		promptedEmail = true
+	}
+
+	if name == "" || email == "" {
+		return "", "", fmt.Errorf("a git committer name and email are required")
+	}
+
+	if promptedName || promptedEmail {
+		globalCfg.Raw.Section("user").SetOption("name", name)
+		globalCfg.Raw.Section("user").SetOption("email", email)
+		if err := saveGlobalGitConfig(globalCfg); err != nil {
+			return "", "", fmt.Errorf("failed to write global git config: %v", err)
+		}
+	}
+
+	return name, email, nil
+}
+
+// saveGlobalGitConfig persists cfg to the user's global git config file.
+// go-git's config package can load and marshal a Config but has no
+// counterpart to LoadConfig for writing one back out, so this writes the
+// marshaled config to the path config.Paths reports for GlobalScope.
+func saveGlobalGitConfig(cfg *config.Config) error {
+	paths, err := config.Paths(config.GlobalScope)
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		return fmt.Errorf("no global git config path available")
+	}
+
+	data, err := cfg.Marshal()
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(paths[0], data, 0644)
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// withSandboxedHome points $HOME at an empty temp directory so
+// resolveGitIdentity's global git config reads/writes never touch the
+// machine running the test.
+func withSandboxedHome(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", "")
+	return home
+}
+
+func TestResolveGitIdentityFromConfigDoesNotTouchGlobalConfig(t *testing.T) {
+	home := withSandboxedHome(t)
+
+	name, email, err := resolveGitIdentity(GitIdentityConfig{Name: "Ada Lovelace", Email: "ada@example.com"})
+	if err != nil {
+		t.Fatalf("resolveGitIdentity returned error: %v", err)
+	}
+	if name != "Ada Lovelace" || email != "ada@example.com" {
+		t.Errorf("resolveGitIdentity() = (%q, %q), want (%q, %q)", name, email, "Ada Lovelace", "ada@example.com")
+	}
+
+	if _, err := os.Stat(filepath.Join(home, ".gitconfig")); !os.IsNotExist(err) {
+		t.Errorf("expected no ~/.gitconfig to be written for a config-driven identity, stat err = %v", err)
+	}
+}
+
+func TestResolveGitIdentityFromExistingGlobalConfig(t *testing.T) {
+	home := withSandboxedHome(t)
+
+	gitconfig := "[user]\n\tname = Grace Hopper\n\temail = grace@example.com\n"
+	if err := os.WriteFile(filepath.Join(home, ".gitconfig"), []byte(gitconfig), 0644); err != nil {
+		t.Fatalf("failed to seed global git config: %v", err)
+	}
+
+	name, email, err := resolveGitIdentity(GitIdentityConfig{})
+	if err != nil {
+		t.Fatalf("resolveGitIdentity returned error: %v", err)
+	}
+	if name != "Grace Hopper" || email != "grace@example.com" {
+		t.Errorf("resolveGitIdentity() = (%q, %q), want (%q, %q)", name, email, "Grace Hopper", "grace@example.com")
+	}
+
+	got, err := os.ReadFile(filepath.Join(home, ".gitconfig"))
+	if err != nil {
+		t.Fatalf("failed to read global git config back: %v", err)
+	}
+	if string(got) != gitconfig {
+		t.Errorf("resolveGitIdentity modified an existing global git config:\ngot:  %q\nwant: %q", got, gitconfig)
+	}
+}
+
+func TestResolveGitIdentityPromptedIsSavedToGlobalConfig(t *testing.T) {
+	home := withSandboxedHome(t)
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("failed to open stdin pipe: %v", err)
+	}
+	prevStdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = prevStdin }()
+
+	go func() {
+		defer w.Close()
+		// fmt.Scanln reads a single whitespace-delimited token per call, so
+		// this mirrors how resolveGitIdentity's prompts are actually read.
+		w.WriteString("Alan\nalan@example.com\n")
+	}()
+
+	name, email, err := resolveGitIdentity(GitIdentityConfig{})
+	if err != nil {
+		t.Fatalf("resolveGitIdentity returned error: %v", err)
+	}
+	if name != "Alan" || email != "alan@example.com" {
+		t.Errorf("resolveGitIdentity() = (%q, %q), want (%q, %q)", name, email, "Alan", "alan@example.com")
+	}
+
+	got, err := os.ReadFile(filepath.Join(home, ".gitconfig"))
+	if err != nil {
+		t.Fatalf("expected prompted identity to be saved to global git config: %v", err)
+	}
+	if !strings.Contains(string(got), "Alan") || !strings.Contains(string(got), "alan@example.com") {
+		t.Errorf("saved global git config missing prompted identity, got: %q", got)
+	}
+}
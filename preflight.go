@@ -0,0 +1,125 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+)
+
+// defaultPreflightTimeout bounds how long runPreflightChecks waits for a
+// provider's reachability and auth checks to respond before giving up.
+const defaultPreflightTimeout = 10 * time.Second
+
+// Typed pre-flight errors, so callers can tell "your credentials are wrong"
+// apart from "the provider didn't respond in time" apart from "the host
+// doesn't exist".
+var (
+	// ErrGitAuth is returned by a GitProvider's CheckAuth when its
+	// credentials are missing, invalid, or under-scoped.
+	ErrGitAuth = errors.New("git authentication failed")
+	// ErrGitTimeout is returned by withTimeout when a provider check
+	// doesn't complete within the configured --preflight-timeout.
+	ErrGitTimeout = errors.New("git provider check timed out")
+	// ErrGitUnreachable is returned when a provider's host can't be dialed
+	// at all, as opposed to responding with an auth failure.
+	ErrGitUnreachable = errors.New("git provider is unreachable")
+)
+
+// providerNamesFor returns the set of distinct provider names used across
+// environments, falling back to defaultProviderName for envs that don't
+// override it.
+func providerNamesFor(environments []EnvironmentConfig, defaultProviderName string) []string {
+	seen := make(map[string]bool)
+	var names []string
+
+	for _, env := range environments {
+		name := defaultProviderName
+		if env.Provider != "" {
+			name = env.Provider
+		}
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// runPreflightChecks verifies every git provider in use is reachable and
+// authenticated before any environment directories are created or repos
+// pushed. It's meant to run once, up front — failing fast here is what
+// keeps an unreachable or misauthenticated provider from stalling partway
+// through the environment loop.
+//
+// This used to also `git ls-remote` each provider's BaseURL, but BaseURL is
+// just the host root (e.g. https://github.com), not a repository path, so
+// that check always failed regardless of whether the provider and
+// credentials were actually fine. Each environment's repo doesn't exist
+// until CreateRepo makes it, so there's no real repo URL to check yet;
+// checkHostReachable dials BaseURL's host directly instead, which is enough
+// to distinguish a black-holed or nonexistent host from a bad token.
+func runPreflightChecks(environments []EnvironmentConfig, defaultProviderName string, timeout time.Duration) error {
+	for _, providerName := range providerNamesFor(environments, defaultProviderName) {
+		provider, err := newGitProvider(providerName)
+		if err != nil {
+			return err
+		}
+
+		if err := checkHostReachable(provider.BaseURL(), timeout); err != nil {
+			return err
+		}
+
+		if err := withTimeout(timeout, provider.CheckAuth); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkHostReachable dials baseURL's host to catch a DNS-black-holed or
+// nonexistent provider host before running an auth check that would just
+// hang the same way. The dial and the auth check each get the full timeout.
+func checkHostReachable(baseURL string, timeout time.Duration) error {
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return fmt.Errorf("%w: failed to parse '%s': %v", ErrGitUnreachable, baseURL, err)
+	}
+
+	host := parsed.Host
+	if parsed.Port() == "" {
+		port := "443"
+		if parsed.Scheme == "http" {
+			port = "80"
+		}
+		host = net.JoinHostPort(host, port)
+	}
+
+	conn, err := net.DialTimeout("tcp", host, timeout)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrGitUnreachable, host, err)
+	}
+	conn.Close()
+
+	return nil
+}
+
+// withTimeout runs fn and fails with ErrGitTimeout if it doesn't return
+// within timeout, since CheckAuth implementations use a plain http.Client
+// with no deadline of its own.
+func withTimeout(timeout time.Duration, fn func() error) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- fn()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(timeout):
+		return ErrGitTimeout
+	}
+}
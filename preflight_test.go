@@ -0,0 +1,63 @@
+package main
+
+import (
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestProviderNamesFor(t *testing.T) {
+	environments := []EnvironmentConfig{
+		{Prefix: "SANDBOX_"},
+		{Prefix: "DEV_"},
+		{Prefix: "PREPROD_", Provider: "gitlab"},
+		{Prefix: "PROD_", Provider: "gitlab"},
+		{Prefix: "STAGE_", Provider: "gitea"},
+	}
+
+	got := providerNamesFor(environments, "github")
+	want := []string{"github", "gitlab", "gitea"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("providerNamesFor() = %v, want %v", got, want)
+	}
+}
+
+func TestProviderNamesForAllDefault(t *testing.T) {
+	got := providerNamesFor(defaultEnvironments, "github")
+	want := []string{"github"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("providerNamesFor() = %v, want %v", got, want)
+	}
+}
+
+func TestCheckHostReachableSucceeds(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to open listener: %v", err)
+	}
+	defer ln.Close()
+
+	if err := checkHostReachable("http://"+ln.Addr().String(), time.Second); err != nil {
+		t.Errorf("checkHostReachable() = %v, want nil", err)
+	}
+}
+
+func TestCheckHostReachableFailsOnClosedPort(t *testing.T) {
+	// Open and immediately close a listener to get a port nothing is
+	// listening on anymore.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to open listener: %v", err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	err = checkHostReachable("http://"+addr, time.Second)
+	if !errors.Is(err, ErrGitUnreachable) {
+		t.Errorf("checkHostReachable() = %v, want ErrGitUnreachable", err)
+	}
+}
@@ -0,0 +1,433 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	httptransport "github.com/go-git/go-git/v5/plumbing/transport/http"
+)
+
+// GitProvider abstracts the git hosting backend used to create a remote
+// repository for an environment directory. This lets each environment
+// (SANDBOX_/DEV_/STAGE_/PREPROD_/PROD_) target GitHub, GitLab, Gitea, or
+// Bitbucket instead of the hardcoded api.github.com + AlbersSoftware org.
+type GitProvider interface {
+	// CreateRepo creates a repository named name with the given visibility
+	// and returns its clone URL.
+	CreateRepo(name string, private bool) (cloneURL string, err error)
+	// BaseURL returns the host environment repos are created under.
+	BaseURL() string
+	// CheckAuth verifies the provider's credentials are valid and carry
+	// enough scope to create repositories, without creating one.
+	CheckAuth() error
+	// PushAuth returns the transport.AuthMethod git should use to push to a
+	// repository this provider created, so the credentials used to create a
+	// repo are also the ones used to push to it regardless of --provider.
+	PushAuth() (transport.AuthMethod, error)
+}
+
+// BranchProtector is implemented by providers that support locking a branch
+// down after the fact. Not every provider in newGitProvider implements it;
+// callers should type-assert before using it.
+type BranchProtector interface {
+	ProtectBranch(repoName, branch string) error
+}
+
+// newGitProvider builds the GitProvider selected by --provider, reading its
+// API token (and, for self-hosted backends, its base URL) from the
+// provider's own environment variables.
+func newGitProvider(name string) (GitProvider, error) {
+	switch name {
+	case "", "github":
+		token := os.Getenv("GITHUB_TOKEN")
+		if token == "" {
+			return nil, fmt.Errorf("GITHUB_TOKEN is required for the github provider")
+		}
+		return &GitHubProvider{Token: token}, nil
+
+	case "gitlab":
+		token := os.Getenv("GITLAB_TOKEN")
+		if token == "" {
+			return nil, fmt.Errorf("GITLAB_TOKEN is required for the gitlab provider")
+		}
+		baseURL := os.Getenv("GITLAB_URL")
+		if baseURL == "" {
+			baseURL = "https://gitlab.com"
+		}
+		return &GitLabProvider{Token: token, Host: baseURL}, nil
+
+	case "gitea":
+		token := os.Getenv("GITEA_TOKEN")
+		if token == "" {
+			return nil, fmt.Errorf("GITEA_TOKEN is required for the gitea provider")
+		}
+		baseURL := os.Getenv("GITEA_URL")
+		if baseURL == "" {
+			return nil, fmt.Errorf("GITEA_URL is required for the gitea provider")
+		}
+		return &GiteaProvider{Token: token, Host: baseURL}, nil
+
+	case "bitbucket":
+		username := os.Getenv("BITBUCKET_USERNAME")
+		appPassword := os.Getenv("BITBUCKET_APP_PASSWORD")
+		workspace := os.Getenv("BITBUCKET_WORKSPACE")
+		if username == "" || appPassword == "" || workspace == "" {
+			return nil, fmt.Errorf("BITBUCKET_USERNAME, BITBUCKET_APP_PASSWORD, and BITBUCKET_WORKSPACE are required for the bitbucket provider")
+		}
+		return &BitbucketProvider{Username: username, AppPassword: appPassword, Workspace: workspace}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown git provider '%s'", name)
+	}
+}
+
+// doJSONRequest issues an HTTP request with a JSON body and auth header,
+// returning an error unless the response matches wantStatus.
+func doJSONRequest(method, url string, body interface{}, authHeader string, wantStatus int) (*http.Response, error) {
+	jsonBody, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(method, url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", authHeader)
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != wantStatus {
+		defer resp.Body.Close()
+		return nil, fmt.Errorf("request to %s failed, status code: %d", url, resp.StatusCode)
+	}
+
+	return resp, nil
+}
+
+// defaultGitHubAPIBase is GitHub's real API host. GitHubProvider.apiBase
+// overrides it in tests so CreateRepo/CheckAuth/ProtectBranch can be
+// exercised against an httptest.Server instead of api.github.com.
+const defaultGitHubAPIBase = "https://api.github.com"
+
+// GitHubProvider creates repositories under the AlbersSoftware org via the
+// GitHub REST API. This is the tool's original (and default) behavior.
+type GitHubProvider struct {
+	Token string
+
+	// apiBase overrides defaultGitHubAPIBase in tests; zero value means
+	// "use the real GitHub API".
+	apiBase string
+}
+
+func (p *GitHubProvider) api() string {
+	if p.apiBase != "" {
+		return p.apiBase
+	}
+	return defaultGitHubAPIBase
+}
+
+func (p *GitHubProvider) CreateRepo(name string, private bool) (string, error) {
+	resp, err := doJSONRequest(
+		"POST",
+		p.api()+"/user/repos",
+		map[string]interface{}{"name": name, "private": private},
+		"token "+p.Token,
+		http.StatusCreated,
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to create GitHub repository: %v", err)
+	}
+	defer resp.Body.Close()
+
+	slog.Info("created remote repository", "provider", "github", "env", name)
+	return fmt.Sprintf("https://github.com/AlbersSoftware/%s.git", name), nil
+}
+
+func (p *GitHubProvider) BaseURL() string {
+	return "https://github.com"
+}
+
+func (p *GitHubProvider) PushAuth() (transport.AuthMethod, error) {
+	return &httptransport.BasicAuth{Username: "git", Password: p.Token}, nil
+}
+
+// ProtectBranch enables branch protection on branch, used for PROD_/PREPROD_
+// environments so scaffolded production repos are protected from day one.
+func (p *GitHubProvider) ProtectBranch(repoName, branch string) error {
+	url := fmt.Sprintf("%s/repos/AlbersSoftware/%s/branches/%s/protection", p.api(), repoName, branch)
+
+	body := map[string]interface{}{
+		"required_status_checks":        nil,
+		"enforce_admins":                true,
+		"required_pull_request_reviews": map[string]interface{}{},
+		"restrictions":                  nil,
+	}
+	jsonBody, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("PUT", url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "token "+p.Token)
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to protect branch '%s' on '%s': %v", branch, repoName, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to protect branch '%s' on '%s', status code: %d", branch, repoName, resp.StatusCode)
+	}
+
+	slog.Info("protected branch", "provider", "github", "env", repoName, "branch", branch)
+	return nil
+}
+
+func (p *GitHubProvider) CheckAuth() error {
+	req, err := http.NewRequest("GET", p.api()+"/user", nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "token "+p.Token)
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrGitAuth, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: GET /user returned status %d", ErrGitAuth, resp.StatusCode)
+	}
+
+	scopes := resp.Header.Get("X-OAuth-Scopes")
+	hasRepoScope := false
+	for _, scope := range strings.Split(scopes, ",") {
+		if strings.TrimSpace(scope) == "repo" {
+			hasRepoScope = true
+			break
+		}
+	}
+	if !hasRepoScope {
+		return fmt.Errorf("%w: GITHUB_TOKEN is missing the 'repo' scope (has: %s)", ErrGitAuth, scopes)
+	}
+
+	return nil
+}
+
+// GitLabProvider creates projects via the GitLab REST API, against either
+// gitlab.com or a self-hosted instance (GITLAB_URL).
+type GitLabProvider struct {
+	Token string
+	Host  string
+}
+
+func (p *GitLabProvider) CreateRepo(name string, private bool) (string, error) {
+	visibility := "public"
+	if private {
+		visibility = "private"
+	}
+
+	resp, err := doJSONRequest(
+		"POST",
+		p.Host+"/api/v4/projects",
+		map[string]interface{}{"name": name, "visibility": visibility},
+		"Bearer "+p.Token,
+		http.StatusCreated,
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to create GitLab project: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var project struct {
+		HTTPURLToRepo string `json:"http_url_to_repo"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&project); err != nil {
+		return "", fmt.Errorf("failed to parse GitLab project response: %v", err)
+	}
+	if project.HTTPURLToRepo == "" {
+		return "", fmt.Errorf("GitLab project response did not include http_url_to_repo")
+	}
+
+	slog.Info("created remote repository", "provider", "gitlab", "env", name, "host", p.Host)
+	return project.HTTPURLToRepo, nil
+}
+
+func (p *GitLabProvider) BaseURL() string {
+	return p.Host
+}
+
+func (p *GitLabProvider) PushAuth() (transport.AuthMethod, error) {
+	return &httptransport.BasicAuth{Username: "oauth2", Password: p.Token}, nil
+}
+
+func (p *GitLabProvider) CheckAuth() error {
+	return checkTokenAuth(p.Host+"/api/v4/user", "Bearer "+p.Token)
+}
+
+// GiteaProvider creates repositories via the Gitea REST API against a
+// self-hosted instance (GITEA_URL).
+type GiteaProvider struct {
+	Token string
+	Host  string
+}
+
+func (p *GiteaProvider) CreateRepo(name string, private bool) (string, error) {
+	resp, err := doJSONRequest(
+		"POST",
+		p.Host+"/api/v1/user/repos",
+		map[string]interface{}{"name": name, "private": private},
+		"token "+p.Token,
+		http.StatusCreated,
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to create Gitea repository: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var repository struct {
+		CloneURL string `json:"clone_url"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&repository); err != nil {
+		return "", fmt.Errorf("failed to parse Gitea repository response: %v", err)
+	}
+	if repository.CloneURL == "" {
+		return "", fmt.Errorf("Gitea repository response did not include clone_url")
+	}
+
+	slog.Info("created remote repository", "provider", "gitea", "env", name, "host", p.Host)
+	return repository.CloneURL, nil
+}
+
+func (p *GiteaProvider) BaseURL() string {
+	return p.Host
+}
+
+func (p *GiteaProvider) PushAuth() (transport.AuthMethod, error) {
+	return &httptransport.BasicAuth{Username: "git", Password: p.Token}, nil
+}
+
+func (p *GiteaProvider) CheckAuth() error {
+	return checkTokenAuth(p.Host+"/api/v1/user", "token "+p.Token)
+}
+
+// defaultBitbucketAPIBase is Bitbucket Cloud's real API host.
+// BitbucketProvider.apiBase overrides it in tests so CreateRepo/CheckAuth
+// can be exercised against an httptest.Server instead of api.bitbucket.org.
+const defaultBitbucketAPIBase = "https://api.bitbucket.org"
+
+// BitbucketProvider creates repositories via the Bitbucket Cloud REST API
+// under a workspace, authenticating with an app password.
+type BitbucketProvider struct {
+	Username    string
+	AppPassword string
+	Workspace   string
+
+	// apiBase overrides defaultBitbucketAPIBase in tests; zero value means
+	// "use the real Bitbucket API".
+	apiBase string
+}
+
+func (p *BitbucketProvider) api() string {
+	if p.apiBase != "" {
+		return p.apiBase
+	}
+	return defaultBitbucketAPIBase
+}
+
+func (p *BitbucketProvider) CreateRepo(name string, private bool) (string, error) {
+	url := fmt.Sprintf("%s/2.0/repositories/%s/%s", p.api(), p.Workspace, name)
+
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer([]byte(fmt.Sprintf(`{"scm":"git","is_private":%t}`, private))))
+	if err != nil {
+		return "", err
+	}
+	req.SetBasicAuth(p.Username, p.AppPassword)
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to create Bitbucket repository: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("failed to create Bitbucket repository, status code: %d", resp.StatusCode)
+	}
+
+	slog.Info("created remote repository", "provider", "bitbucket", "env", name, "workspace", p.Workspace)
+	return fmt.Sprintf("https://bitbucket.org/%s/%s.git", p.Workspace, name), nil
+}
+
+func (p *BitbucketProvider) BaseURL() string {
+	return "https://bitbucket.org"
+}
+
+func (p *BitbucketProvider) PushAuth() (transport.AuthMethod, error) {
+	return &httptransport.BasicAuth{Username: p.Username, Password: p.AppPassword}, nil
+}
+
+func (p *BitbucketProvider) CheckAuth() error {
+	req, err := http.NewRequest("GET", p.api()+"/2.0/user", nil)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(p.Username, p.AppPassword)
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrGitAuth, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: GET /2.0/user returned status %d", ErrGitAuth, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// checkTokenAuth issues a GET request with authHeader and treats any
+// non-200 response or transport failure as an auth problem.
+func checkTokenAuth(url, authHeader string) error {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", authHeader)
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrGitAuth, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: %s returned status %d", ErrGitAuth, url, resp.StatusCode)
+	}
+
+	return nil
+}
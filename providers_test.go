@@ -0,0 +1,176 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGitHubProviderCreateRepo(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/user/repos" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "token tok" {
+			t.Errorf("Authorization = %q, want %q", got, "token tok")
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	p := &GitHubProvider{Token: "tok"}
+	p.apiBase = srv.URL
+
+	cloneURL, err := p.CreateRepo("myenv", true)
+	if err != nil {
+		t.Fatalf("CreateRepo returned error: %v", err)
+	}
+	want := "https://github.com/AlbersSoftware/myenv.git"
+	if cloneURL != want {
+		t.Errorf("CreateRepo() = %q, want %q", cloneURL, want)
+	}
+}
+
+func TestGitLabProviderCreateRepoUsesResponseCloneURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v4/projects" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer tok" {
+			t.Errorf("Authorization = %q, want %q", got, "Bearer tok")
+		}
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]string{
+			"http_url_to_repo": "http://gitlab.example.com/someuser/myenv.git",
+		})
+	}))
+	defer srv.Close()
+
+	p := &GitLabProvider{Token: "tok", Host: srv.URL}
+
+	cloneURL, err := p.CreateRepo("myenv", true)
+	if err != nil {
+		t.Fatalf("CreateRepo returned error: %v", err)
+	}
+	want := "http://gitlab.example.com/someuser/myenv.git"
+	if cloneURL != want {
+		t.Errorf("CreateRepo() = %q, want %q (not a guessed <host>/<name>.git)", cloneURL, want)
+	}
+}
+
+func TestGitLabProviderCreateRepoMissingCloneURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]string{})
+	}))
+	defer srv.Close()
+
+	p := &GitLabProvider{Token: "tok", Host: srv.URL}
+
+	if _, err := p.CreateRepo("myenv", true); err == nil {
+		t.Fatal("CreateRepo returned nil error, want error for missing http_url_to_repo")
+	}
+}
+
+func TestGiteaProviderCreateRepoUsesResponseCloneURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/user/repos" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]string{
+			"clone_url": "http://gitea.example.com/someuser/myenv.git",
+		})
+	}))
+	defer srv.Close()
+
+	p := &GiteaProvider{Token: "tok", Host: srv.URL}
+
+	cloneURL, err := p.CreateRepo("myenv", true)
+	if err != nil {
+		t.Fatalf("CreateRepo returned error: %v", err)
+	}
+	want := "http://gitea.example.com/someuser/myenv.git"
+	if cloneURL != want {
+		t.Errorf("CreateRepo() = %q, want %q (not a guessed <host>/<name>.git)", cloneURL, want)
+	}
+}
+
+func TestGiteaProviderCreateRepoMissingCloneURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]string{})
+	}))
+	defer srv.Close()
+
+	p := &GiteaProvider{Token: "tok", Host: srv.URL}
+
+	if _, err := p.CreateRepo("myenv", true); err == nil {
+		t.Fatal("CreateRepo returned nil error, want error for missing clone_url")
+	}
+}
+
+func TestBitbucketProviderCreateRepo(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/2.0/repositories/myworkspace/myenv" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "user" || pass != "pass" {
+			t.Errorf("BasicAuth() = (%q, %q, %v), want (%q, %q, true)", user, pass, ok, "user", "pass")
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	p := &BitbucketProvider{Username: "user", AppPassword: "pass", Workspace: "myworkspace"}
+	p.apiBase = srv.URL
+
+	cloneURL, err := p.CreateRepo("myenv", true)
+	if err != nil {
+		t.Fatalf("CreateRepo returned error: %v", err)
+	}
+	want := "https://bitbucket.org/myworkspace/myenv.git"
+	if cloneURL != want {
+		t.Errorf("CreateRepo() = %q, want %q", cloneURL, want)
+	}
+}
+
+func TestCheckTokenAuthFailsOnNon200(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	err := checkTokenAuth(srv.URL+"/api/v4/user", "Bearer bad-token")
+	if err == nil {
+		t.Fatal("checkTokenAuth returned nil error, want error")
+	}
+}
+
+func TestCheckTokenAuthSucceeds(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	if err := checkTokenAuth(srv.URL+"/api/v4/user", "Bearer tok"); err != nil {
+		t.Errorf("checkTokenAuth returned error: %v", err)
+	}
+}
+
+func TestProviderPushAuth(t *testing.T) {
+	providers := []GitProvider{
+		&GitHubProvider{Token: "tok"},
+		&GitLabProvider{Token: "tok", Host: "https://gitlab.example.com"},
+		&GiteaProvider{Token: "tok", Host: "https://gitea.example.com"},
+		&BitbucketProvider{Username: "user", AppPassword: "pass", Workspace: "ws"},
+	}
+
+	for _, p := range providers {
+		if _, err := p.PushAuth(); err != nil {
+			t.Errorf("%T.PushAuth() returned error: %v", p, err)
+		}
+	}
+}
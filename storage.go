@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"cloud.google.com/go/storage"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// sinkUploadWorkers bounds how many files are streamed to a remote sink at
+// once per environment, mirroring the one-goroutine-per-env concurrency the
+// tool already uses at the solution level.
+const sinkUploadWorkers = 4
+
+// Sink is a destination environment snapshots can be written to. Remote
+// object storage (S3, GCS) implements it; the local filesystem does not,
+// and local snapshots are written by copyDirectory instead.
+type Sink interface {
+	// WriteFile writes the contents of r to key.
+	WriteFile(key string, r io.Reader) error
+}
+
+// newSink parses --storage and returns the Sink it describes. An empty
+// storageURL means "no remote sink" (nil, nil) and callers should fall back
+// to copyDirectory against the local filesystem.
+func newSink(storageURL string) (Sink, error) {
+	if storageURL == "" {
+		return nil, nil
+	}
+
+	parsed, err := url.Parse(storageURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse --storage URL '%s': %v", storageURL, err)
+	}
+
+	bucket := parsed.Host
+	prefix := strings.TrimPrefix(parsed.Path, "/")
+
+	switch parsed.Scheme {
+	case "s3":
+		cfg, err := awsconfig.LoadDefaultConfig(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load AWS config: %v", err)
+		}
+		return &s3Sink{client: s3.NewFromConfig(cfg), bucket: bucket, prefix: prefix}, nil
+
+	case "gs":
+		client, err := storage.NewClient(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create GCS client: %v", err)
+		}
+		return &gcsSink{client: client, bucket: bucket, prefix: prefix}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported --storage scheme '%s' (expected s3:// or gs://)", parsed.Scheme)
+	}
+}
+
+// objectKey joins a sink's prefix with a relative key using "/", since
+// object storage keys are not filesystem paths.
+func objectKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "/" + key
+}
+
+// s3Sink writes environment snapshots to an S3 bucket.
+type s3Sink struct {
+	client *s3.Client
+	bucket string
+	prefix string
+}
+
+func (s *s3Sink) WriteFile(key string, r io.Reader) error {
+	_, err := s.client.PutObject(context.Background(), &s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(objectKey(s.prefix, key)),
+		Body:   r,
+	})
+	return err
+}
+
+// gcsSink writes environment snapshots to a GCS bucket.
+type gcsSink struct {
+	client *storage.Client
+	bucket string
+	prefix string
+}
+
+func (s *gcsSink) WriteFile(key string, r io.Reader) error {
+	w := s.client.Bucket(s.bucket).Object(objectKey(s.prefix, key)).NewWriter(context.Background())
+	if _, err := io.Copy(w, r); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// copyDirectoryToSink walks src and streams each file to sink under
+// keyPrefix/<relPath>, using a bounded worker pool so a single environment
+// doesn't open unbounded concurrent uploads.
+func copyDirectoryToSink(src string, sink Sink, keyPrefix string, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	type uploadJob struct {
+		path    string
+		relPath string
+	}
+
+	jobs := make(chan uploadJob)
+	var uploadErr error
+	var mu sync.Mutex
+
+	var workers sync.WaitGroup
+	for i := 0; i < sinkUploadWorkers; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for job := range jobs {
+				if err := uploadOneFile(job.path, sink, keyPrefix, job.relPath); err != nil {
+					mu.Lock()
+					if uploadErr == nil {
+						uploadErr = err
+					}
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	walkErr := filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+
+		jobs <- uploadJob{path: path, relPath: relPath}
+		return nil
+	})
+
+	close(jobs)
+	workers.Wait()
+
+	if walkErr != nil {
+		slog.Error("failed to walk directory", "src", src, "error", walkErr)
+	} else if uploadErr != nil {
+		slog.Error("failed to upload directory to remote storage", "src", src, "error", uploadErr)
+	}
+}
+
+func uploadOneFile(path string, sink Sink, keyPrefix, relPath string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	key := filepath.ToSlash(filepath.Join(keyPrefix, relPath))
+	return sink.WriteFile(key, file)
+}
@@ -0,0 +1,21 @@
+package main
+
+import "testing"
+
+func TestObjectKey(t *testing.T) {
+	cases := []struct {
+		prefix string
+		key    string
+		want   string
+	}{
+		{prefix: "", key: "SANDBOX_foo/file.txt", want: "SANDBOX_foo/file.txt"},
+		{prefix: "snapshots", key: "SANDBOX_foo/file.txt", want: "snapshots/SANDBOX_foo/file.txt"},
+		{prefix: "a/b", key: "c/d.txt", want: "a/b/c/d.txt"},
+	}
+
+	for _, tc := range cases {
+		if got := objectKey(tc.prefix, tc.key); got != tc.want {
+			t.Errorf("objectKey(%q, %q) = %q, want %q", tc.prefix, tc.key, got, tc.want)
+		}
+	}
+}